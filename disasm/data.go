@@ -0,0 +1,211 @@
+package disasm
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// minStrLen is the minimum number of printable characters of a string.
+	minStrLen = 4
+	// minZeroRun is the minimum number of zero bytes rendered as a fill.
+	minZeroRun = 4
+	// minPtrs is the minimum number of entries of a code pointer table.
+	minPtrs = 2
+	// bytesPerLine is the number of raw bytes rendered per db line.
+	bytesPerLine = 8
+)
+
+// dataKind is a kind of data region.
+type dataKind int
+
+// Data kinds.
+const (
+	dataBytes  dataKind = iota // raw bytes
+	dataString                 // text without terminator
+	dataASCIZ                  // text terminated by NUL
+	dataDOSStr                 // text terminated by '$'
+	dataZero                   // zero fill
+	dataPtrs                   // table of code pointers
+)
+
+// dataRegion is a run of data bytes of one kind starting at off.
+type dataRegion struct {
+	off  int
+	bs   []byte
+	kind dataKind
+}
+
+// classifyData splits bs, which starts at offset off, into data regions.
+// isCode reports whether an offset is known to be code; it may be nil,
+// in which case no code pointer tables are recognized.
+func classifyData(off int, bs []byte, isCode func(int) bool) []dataRegion {
+	var rs []dataRegion
+
+	add := func(i, n int, k dataKind) {
+		// merge adjacent raw bytes
+		if k == dataBytes && len(rs) > 0 {
+			last := &rs[len(rs)-1]
+			if last.kind == dataBytes && last.off+len(last.bs) == off+i {
+				last.bs = bs[i-len(last.bs) : i+n]
+				return
+			}
+		}
+		rs = append(rs, dataRegion{off: off + i, bs: bs[i : i+n], kind: k})
+	}
+
+	for i := 0; i < len(bs); {
+		if n := zeroRun(bs[i:]); n >= minZeroRun {
+			add(i, n, dataZero)
+			i += n
+			continue
+		}
+
+		if n, k := strRun(bs[i:]); n > 0 {
+			add(i, n, k)
+			i += n
+			continue
+		}
+
+		if n := ptrRun(bs[i:], isCode); n >= minPtrs {
+			add(i, 2*n, dataPtrs)
+			i += 2 * n
+			continue
+		}
+
+		add(i, 1, dataBytes)
+		i++
+	}
+
+	return rs
+}
+
+// ptrRun returns the number of consecutive little endian words at the head of bs
+// that point to code. Zero words are not taken as pointers.
+func ptrRun(bs []byte, isCode func(int) bool) int {
+	if isCode == nil {
+		return 0
+	}
+	n := 0
+	for ; 2*n+1 < len(bs); n++ {
+		if w := word(bs[2*n:]); w == 0 || !isCode(int(w)) {
+			break
+		}
+	}
+	return n
+}
+
+// strRun returns the length and kind of the string at the head of bs,
+// or 0 if it has fewer than minStrLen characters. A NUL right after the
+// printable run makes a C string even if the run contains '$', unless the
+// run ends in '$' (a DOS string followed by padding). Otherwise the run
+// ends after its first '$' as a DOS string.
+func strRun(bs []byte) (int, dataKind) {
+	n := printRun(bs)
+	switch {
+	case n < minStrLen:
+		return 0, dataBytes
+	case bs[n-1] == '$' && strings.IndexByte(string(bs[:n]), '$') == n-1:
+		return n, dataDOSStr
+	case n < len(bs) && bs[n] == 0:
+		return n + 1, dataASCIZ
+	}
+
+	if k := strings.IndexByte(string(bs[:n]), '$'); k >= 0 {
+		if k+1 < minStrLen {
+			return 0, dataBytes
+		}
+		return k + 1, dataDOSStr
+	}
+	return n, dataString
+}
+
+// printRun returns the number of printable characters at the head of bs.
+func printRun(bs []byte) int {
+	n := 0
+	for ; n < len(bs) && isPrint(bs[n]); n++ {
+	}
+	return n
+}
+
+// zeroRun returns the number of zero bytes at the head of bs.
+func zeroRun(bs []byte) int {
+	n := 0
+	for ; n < len(bs) && bs[n] == 0; n++ {
+	}
+	return n
+}
+
+// isPrint reports whether b is a printable ASCII character or a common control character in text.
+func isPrint(b byte) bool {
+	return b >= 0x20 && b < 0x7F || b == '\t' || b == '\r' || b == '\n'
+}
+
+// word returns the little endian word at the head of bs.
+func word(bs []byte) uint16 {
+	return uint16(bs[1])<<8 | uint16(bs[0])
+}
+
+// lines renders r as assembler data directives.
+func (r dataRegion) lines() []string {
+	switch r.kind {
+	case dataString, dataASCIZ, dataDOSStr:
+		return []string{"db " + quote(r.bs)}
+	case dataZero:
+		return []string{fmt.Sprintf("times %d db 0", len(r.bs))}
+	case dataPtrs:
+		ls := make([]string, 0, len(r.bs)/2)
+		for i := 0; i+1 < len(r.bs); i += 2 {
+			ls = append(ls, fmt.Sprintf("dw loc_%04X", word(r.bs[i:])))
+		}
+		return ls
+	default:
+		var ls []string
+		for i := 0; i < len(r.bs); i += bytesPerLine {
+			end := i + bytesPerLine
+			if end > len(r.bs) {
+				end = len(r.bs)
+			}
+			ss := make([]string, 0, end-i)
+			for _, b := range r.bs[i:end] {
+				ss = append(ss, byteStr(b))
+			}
+			ls = append(ls, "db "+strings.Join(ss, ","))
+		}
+		return ls
+	}
+}
+
+// quote renders bs as a comma separated list of quoted text and byte values,
+// e.g. "Hello",0xd,0xa,"$".
+func quote(bs []byte) string {
+	var ss []string
+	var text []byte
+
+	flush := func() {
+		if len(text) > 0 {
+			ss = append(ss, `"`+string(text)+`"`)
+			text = text[:0]
+		}
+	}
+
+	for _, b := range bs {
+		if b >= 0x20 && b < 0x7F && b != '"' {
+			text = append(text, b)
+			continue
+		}
+		flush()
+		ss = append(ss, byteStr(b))
+	}
+	flush()
+
+	return strings.Join(ss, ",")
+}
+
+// byteStr renders b as a hexadecimal byte value, or 0 for a zero byte.
+func byteStr(b byte) string {
+	if b == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%#x", b)
+}
@@ -0,0 +1,124 @@
+package disasm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyData(t *testing.T) {
+	bs := []byte{
+		0x01, 0x02, // raw bytes
+		'c', 'c', ':', ' ', 0x00, // ASCIZ
+		'O', 'K', '\r', '\n', '$', // DOS string
+		0x00, 0x00, 0x00, 0x00, 0x00, // zero fill
+		0x54, 0x01, 0xDF, 0x11, // code pointers
+		0xFF, // raw byte
+	}
+	code := map[int]bool{0x154: true, 0x11df: true}
+
+	got := classifyData(0x100, bs, func(off int) bool { return code[off] })
+
+	want := []struct {
+		off   int
+		kind  dataKind
+		lines []string
+	}{
+		{0x100, dataBytes, []string{"db 0x1,0x2"}},
+		{0x102, dataASCIZ, []string{`db "cc: ",0`}},
+		{0x107, dataDOSStr, []string{`db "OK",0xd,0xa,"$"`}},
+		{0x10C, dataZero, []string{"times 5 db 0"}},
+		{0x111, dataPtrs, []string{"dw loc_0154", "dw loc_11DF"}},
+		{0x115, dataBytes, []string{"db 0xff"}},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v regions; want %v: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.off != w.off || g.kind != w.kind {
+			t.Errorf("region %v: got off %#x kind %v; want %#x %v", i, g.off, g.kind, w.off, w.kind)
+		}
+		if ls := g.lines(); !reflect.DeepEqual(ls, w.lines) {
+			t.Errorf("region %v: got %q; want %q", i, ls, w.lines)
+		}
+	}
+}
+
+func TestClassifyDataWithoutCode(t *testing.T) {
+	got := classifyData(0, []byte{0x54, 0x01, 0xDF, 0x11}, nil)
+	if len(got) != 1 || got[0].kind != dataBytes {
+		t.Errorf("got %+v; want a single raw byte region", got)
+	}
+}
+
+func TestClassifyDataDOSStrings(t *testing.T) {
+	bs := []byte("Hello$World!$Bye, World")
+
+	want := []struct {
+		kind  dataKind
+		lines []string
+	}{
+		{dataDOSStr, []string{`db "Hello$"`}},
+		{dataDOSStr, []string{`db "World!$"`}},
+		{dataString, []string{`db "Bye, World"`}},
+	}
+
+	got := classifyData(0, bs, nil)
+	if len(got) != len(want) {
+		t.Fatalf("got %v regions; want %v: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].kind != w.kind || !reflect.DeepEqual(got[i].lines(), w.lines) {
+			t.Errorf("region %v: got kind %v %q; want %v %q", i, got[i].kind, got[i].lines(), w.kind, w.lines)
+		}
+	}
+}
+
+func TestClassifyDataCString(t *testing.T) {
+	bs := append([]byte("cost: $%d\n"), 0, 'O', 'K', '!', '$', 0)
+
+	got := classifyData(0, bs, nil)
+	want := []struct {
+		kind  dataKind
+		lines []string
+	}{
+		{dataASCIZ, []string{`db "cost: $%d",0xa,0`}},
+		{dataDOSStr, []string{`db "OK!$"`}},
+		{dataBytes, []string{"db 0"}},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v regions; want %v: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].kind != w.kind || !reflect.DeepEqual(got[i].lines(), w.lines) {
+			t.Errorf("region %v: got kind %v %q; want %v %q", i, got[i].kind, got[i].lines(), w.kind, w.lines)
+		}
+	}
+}
+
+func TestClassifyDataWideIsCode(t *testing.T) {
+	// isCode accepts the string and the zero words, like a code range query does
+	bs := append([]byte("Hello, world"), 0, 0, 0, 0, 0, 0, 0, 0x54, 0x01, 0xDF, 0x11)
+	isCode := func(off int) bool { return off < 0x8000 }
+
+	got := classifyData(0, bs, isCode)
+	want := []struct {
+		kind  dataKind
+		lines []string
+	}{
+		{dataASCIZ, []string{`db "Hello, world",0`}},
+		{dataZero, []string{"times 6 db 0"}},
+		{dataPtrs, []string{"dw loc_0154", "dw loc_11DF"}},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v regions; want %v: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].kind != w.kind || !reflect.DeepEqual(got[i].lines(), w.lines) {
+			t.Errorf("region %v: got kind %v %q; want %v %q", i, got[i].kind, got[i].lines(), w.kind, w.lines)
+		}
+	}
+}
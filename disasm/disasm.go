@@ -42,10 +42,16 @@ type Disasm struct {
 	wtr    io.Writer
 	offset int // offset
 	cmd    *command
+	opts   Options
 }
 
-// New returns a new Disasm.
+// New returns a new Disasm with the default layout.
 func New(r *bufio.Reader, w io.Writer) *Disasm {
+	return NewWithOptions(r, w, Options{})
+}
+
+// NewWithOptions returns a new Disasm with the layout configured by o.
+func NewWithOptions(r *bufio.Reader, w io.Writer, o Options) *Disasm {
 	return &Disasm{
 		rdr:    r,
 		wtr:    w,
@@ -53,11 +59,12 @@ func New(r *bufio.Reader, w io.Writer) *Disasm {
 		cmd: &command{
 			bs: make([]byte, 1, maxLenFolInstCod),
 		},
+		opts: o,
 	}
 }
 
 // modrm interprets [mod *** r/m] byte immediately following the opcode.
-func modrm(bs []byte) (string, error) {
+func (o Options) modrm(bs []byte) (string, error) {
 	if len(bs) < 1 || len(bs) > maxLenFolInstCod {
 		return "", fmt.Errorf("the length of %X is invalid", bs)
 	}
@@ -73,19 +80,19 @@ func modrm(bs []byte) (string, error) {
 			if len(bs) != maxLenFolInstCod {
 				return "", modrmErr(rm, bs, maxLenFolInstCod)
 			}
-			s := fmt.Sprintf("[0x%02x%02x]", bs[2], bs[1])
+			s := fmt.Sprintf("[%s]", o.hex(uint(bs[2])<<8|uint(bs[1]), 4))
 			return s, nil
 		}
 		// the length of bs following 00****** (except 00***110) should be 1
 		if len(bs) != 1 {
 			return "", modrmErr(rm, bs, 1)
 		}
-		return fmt.Sprintf("[%v]", regm[rm]), nil
+		return fmt.Sprintf("[%v]", o.name(regm[rm])), nil
 	case 0x1: // mod = 01
 		if len(bs) != maxLenFolInstCod-1 {
 			return "", modrmErr(rm, bs, maxLenFolInstCod-1)
 		}
-		s := fmt.Sprintf("[%v%+#x]", regm[rm], int8(bs[1]))
+		s := fmt.Sprintf("[%v%v]", o.name(regm[rm]), o.disp(int(int8(bs[1])), 8))
 		return s, nil
 	case 0x2: // mod = 10
 		if len(bs) != maxLenFolInstCod {
@@ -93,10 +100,10 @@ func modrm(bs []byte) (string, error) {
 		}
 		// little endian
 		disp := (int16(bs[2]) << 8) | int16(bs[1])
-		s := fmt.Sprintf("[%v%+#x]", regm[rm], disp)
+		s := fmt.Sprintf("[%v%v]", o.name(regm[rm]), o.disp(int(disp), 16))
 		return s, nil
 	case 0x3: // mod = 11
-		return reg16[rm], nil
+		return o.name(reg16[rm]), nil
 	default:
 		return "", fmt.Errorf("either mod = %v or r/m = %v is invalid", mod, rm)
 	}
@@ -108,8 +115,30 @@ func modrmErr(rm byte, bs []byte, l int) error {
 }
 
 // cmdStr returns an disassembled code.
-func cmdStr(off int, bs []byte, mnem Mnemonic, w, opr1, opr2 string) string {
-	return fmt.Sprintf("%08X  %X   %s %s%s%s", off, bs, mnem.String(), w, opr1, opr2)
+// Bytes beyond o.ByteCols continue on the following lines.
+func (o Options) cmdStr(off int, bs []byte, mnem Mnemonic, w, opr1, opr2 string) string {
+	asm := fmt.Sprintf("%s %s%s%s", o.name(mnem.String()), o.name(w), opr1, opr2)
+
+	switch {
+	case o.NoBytes:
+		return fmt.Sprintf("%s  %s", o.addr(off), asm)
+	case o.ByteCols <= 0:
+		return fmt.Sprintf("%s  %X   %s", o.addr(off), bs, asm)
+	}
+
+	n := o.ByteCols
+	if n > len(bs) {
+		n = len(bs)
+	}
+	s := fmt.Sprintf("%s  %-*X   %s", o.addr(off), 2*o.ByteCols, bs[:n], asm)
+	for i := n; i < len(bs); i += o.ByteCols {
+		end := i + o.ByteCols
+		if end > len(bs) {
+			end = len(bs)
+		}
+		s += fmt.Sprintf("\n%s  %X", o.addr(off+i), bs[i:end])
+	}
+	return s
 }
 
 // Parse parses a set of opcode and operand to an assembly operation.
@@ -133,15 +162,15 @@ func (d *Disasm) parse(b byte) (string, error) {
 		if _, e := d.rdr.Read(d.cmd.bs); e != nil {
 			return "", fmt.Errorf("Read() failed: %v", e)
 		}
-		opr, err := modrm(d.cmd.bs)
+		opr, err := d.opts.modrm(d.cmd.bs)
 		if err != nil {
 			return "", fmt.Errorf("modrm(%v) failed: %v", d.cmd.bs, err)
 		}
-		return cmdStr(d.offset, d.cmd.bs, inc, "word ", opr, ""), nil
+		return d.opts.cmdStr(d.offset, d.cmd.bs, inc, "word ", opr, ""), nil
 	case b>>3 == 0x8: // 01000reg
 		d.offset++
 		reg := b & 0x7
-		return cmdStr(d.offset, nil, inc, "", reg16[reg], ""), nil
+		return d.opts.cmdStr(d.offset, nil, inc, "", d.opts.name(reg16[reg]), ""), nil
 	}
 	d.offset++
 	return "", nil
@@ -28,7 +28,7 @@ func TestModrmNomal(t *testing.T) {
 	}
 
 	for _, tt := range modrmTests {
-		got, err := modrm(tt.bs)
+		got, err := (Options{}).modrm(tt.bs)
 		if err != nil {
 			t.Errorf("error in modrm(%v): %v", tt.bs, err)
 		}
@@ -46,7 +46,7 @@ func TestModrmError(t *testing.T) {
 	}
 
 	for _, tt := range modrmTests {
-		if _, e := modrm(tt); e == nil {
+		if _, e := (Options{}).modrm(tt); e == nil {
 			t.Errorf("error should occur", tt)
 		}
 	}
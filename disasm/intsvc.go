@@ -0,0 +1,146 @@
+package disasm
+
+import "fmt"
+
+// intTable is a table of services of a software interrupt selected by AH.
+type intTable struct {
+	name string
+	svcs map[byte]string
+}
+
+// intTables are the tables of DOS and BIOS services by interrupt number.
+var intTables = map[byte]intTable{
+	0x10: {"BIOS video", map[byte]string{
+		0x00: "set video mode AL",
+		0x01: "set cursor shape CH-CL",
+		0x02: "set cursor position DH,DL on page BH",
+		0x03: "get cursor position of page BH",
+		0x05: "select display page AL",
+		0x06: "scroll up AL lines in CH,CL-DH,DL",
+		0x07: "scroll down AL lines in CH,CL-DH,DL",
+		0x08: "read character and attribute on page BH",
+		0x09: "write character AL with attribute BL CX times",
+		0x0A: "write character AL CX times",
+		0x0B: "set palette BL",
+		0x0C: "write pixel AL at CX,DX",
+		0x0D: "read pixel at CX,DX",
+		0x0E: "teletype output AL",
+		0x0F: "get video mode",
+		0x10: "set palette registers",
+		0x13: "write string ES:BP of length CX at DH,DL",
+	}},
+	0x13: {"BIOS disk", map[byte]string{
+		0x00: "reset disk system DL",
+		0x01: "get status of last operation on DL",
+		0x02: "read AL sectors from CH,DH,CL of DL into ES:BX",
+		0x03: "write AL sectors from ES:BX to CH,DH,CL of DL",
+		0x04: "verify AL sectors at CH,DH,CL of DL",
+		0x05: "format track CH,DH of DL",
+		0x08: "get drive parameters of DL",
+		0x15: "get disk type of DL",
+		0x16: "detect disk change of DL",
+	}},
+	0x16: {"BIOS keyboard", map[byte]string{
+		0x00: "read key",
+		0x01: "check for key",
+		0x02: "get shift flags",
+		0x05: "store key CX in buffer",
+		0x10: "read extended key",
+		0x11: "check for extended key",
+		0x12: "get extended shift flags",
+	}},
+	0x1A: {"BIOS time", map[byte]string{
+		0x00: "get tick count",
+		0x01: "set tick count CX:DX",
+		0x02: "get RTC time",
+		0x03: "set RTC time CH:CL:DH",
+		0x04: "get RTC date",
+		0x05: "set RTC date CX-DH-DL",
+		0x06: "set alarm CH:CL:DH",
+		0x07: "cancel alarm",
+	}},
+	0x21: {"DOS", map[byte]string{
+		0x00: "terminate program",
+		0x01: "read character with echo",
+		0x02: "write character DL",
+		0x03: "read character from AUX",
+		0x04: "write character DL to AUX",
+		0x05: "write character DL to printer",
+		0x06: "direct console I/O DL",
+		0x07: "direct character input",
+		0x08: "read character without echo",
+		0x09: "print string DS:DX",
+		0x0A: "buffered input DS:DX",
+		0x0B: "check input status",
+		0x0C: "flush buffer and read AL",
+		0x0D: "reset disk",
+		0x0E: "select drive DL",
+		0x0F: "open file FCB DS:DX",
+		0x10: "close file FCB DS:DX",
+		0x11: "find first FCB DS:DX",
+		0x12: "find next FCB DS:DX",
+		0x13: "delete file FCB DS:DX",
+		0x14: "sequential read FCB DS:DX",
+		0x15: "sequential write FCB DS:DX",
+		0x16: "create file FCB DS:DX",
+		0x17: "rename file FCB DS:DX",
+		0x19: "get current drive",
+		0x1A: "set DTA DS:DX",
+		0x25: "set interrupt vector AL to DS:DX",
+		0x26: "create PSP at DX",
+		0x2A: "get date",
+		0x2B: "set date CX-DH-DL",
+		0x2C: "get time",
+		0x2D: "set time CH:CL:DH.DL",
+		0x2F: "get DTA",
+		0x30: "get DOS version",
+		0x31: "terminate and stay resident with AL, DX paragraphs",
+		0x33: "get/set Ctrl-Break check AL",
+		0x35: "get interrupt vector AL",
+		0x36: "get free disk space DL",
+		0x39: "create directory DS:DX",
+		0x3A: "remove directory DS:DX",
+		0x3B: "change directory DS:DX",
+		0x3C: "create file DS:DX with attributes CX",
+		0x3D: "open file DS:DX with mode AL",
+		0x3E: "close handle BX",
+		0x3F: "read CX bytes from handle BX into DS:DX",
+		0x40: "write CX bytes from DS:DX to handle BX",
+		0x41: "delete file DS:DX",
+		0x42: "seek handle BX to CX:DX from AL",
+		0x43: "get/set attributes AL of DS:DX",
+		0x44: "IOCTL AL on handle BX",
+		0x45: "duplicate handle BX",
+		0x46: "force duplicate handle BX to CX",
+		0x47: "get current directory of DL into DS:SI",
+		0x48: "allocate BX paragraphs",
+		0x49: "free memory ES",
+		0x4A: "resize memory ES to BX paragraphs",
+		0x4B: "exec DS:DX with ES:BX as AL",
+		0x4C: "terminate with return code AL",
+		0x4D: "get return code",
+		0x4E: "find first DS:DX with attributes CX",
+		0x4F: "find next",
+		0x54: "get verify flag",
+		0x56: "rename file DS:DX to ES:DI",
+		0x57: "get/set file date and time AL of handle BX",
+		0x59: "get extended error",
+		0x5A: "create temporary file in DS:DX",
+		0x5B: "create new file DS:DX",
+		0x62: "get PSP",
+	}},
+}
+
+// intService returns a description of the service AH of the interrupt n,
+// e.g. "DOS: print string DS:DX". It reports false if the service is unknown.
+func intService(n, ah byte) (string, bool) {
+	tbl, ok := intTables[n]
+	if !ok {
+		return "", false
+	}
+	svc, ok := tbl.svcs[ah]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s: %s", tbl.name, svc), true
+}
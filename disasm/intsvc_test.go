@@ -0,0 +1,27 @@
+package disasm
+
+import "testing"
+
+func TestIntService(t *testing.T) {
+	intTests := []struct {
+		n, ah byte
+		want  string
+		ok    bool
+	}{
+		{0x21, 0x09, "DOS: print string DS:DX", true},
+		{0x21, 0x4C, "DOS: terminate with return code AL", true},
+		{0x10, 0x0E, "BIOS video: teletype output AL", true},
+		{0x13, 0x02, "BIOS disk: read AL sectors from CH,DH,CL of DL into ES:BX", true},
+		{0x16, 0x00, "BIOS keyboard: read key", true},
+		{0x1A, 0x00, "BIOS time: get tick count", true},
+		{0x21, 0xFF, "", false},
+		{0x20, 0x00, "", false},
+	}
+
+	for _, tt := range intTests {
+		got, ok := intService(tt.n, tt.ah)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("intService(%#x, %#x) = %q, %v; want %q, %v", tt.n, tt.ah, got, ok, tt.want, tt.ok)
+		}
+	}
+}
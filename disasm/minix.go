@@ -0,0 +1,109 @@
+package disasm
+
+import "fmt"
+
+// minixSysVec is the interrupt vector MINIX uses for message passing.
+const minixSysVec = 0x20
+
+// minixIPC are the message passing primitives passed in CX on int 0x20.
+var minixIPC = map[uint16]string{
+	1: "send",
+	2: "receive",
+	3: "sendrec",
+}
+
+// minixTasks are the destinations passed in AX on int 0x20.
+var minixTasks = map[uint16]string{
+	0: "MM",
+	1: "FS",
+}
+
+// minixCalls are the system call numbers of MINIX (h/callnr.h),
+// set as m_type of the message sent to MM or FS. The message types from 64
+// on (ksig, unpause, ...) are internal to the kernel and are left out.
+var minixCalls = map[uint16]string{
+	1:  "exit",
+	2:  "fork",
+	3:  "read",
+	4:  "write",
+	5:  "open",
+	6:  "close",
+	7:  "wait",
+	8:  "creat",
+	9:  "link",
+	10: "unlink",
+	12: "chdir",
+	13: "time",
+	14: "mknod",
+	15: "chmod",
+	16: "chown",
+	17: "brk",
+	18: "stat",
+	19: "lseek",
+	20: "getpid",
+	21: "mount",
+	22: "umount",
+	23: "setuid",
+	24: "getuid",
+	25: "stime",
+	27: "alarm",
+	28: "fstat",
+	29: "pause",
+	30: "utime",
+	33: "access",
+	36: "sync",
+	37: "kill",
+	41: "dup",
+	42: "pipe",
+	43: "times",
+	46: "setgid",
+	47: "getgid",
+	48: "signal",
+	54: "ioctl",
+	59: "exec",
+	60: "umask",
+	61: "chroot",
+}
+
+// minixStubs are the C library stubs whose names differ from their system calls.
+var minixStubs = map[uint16]string{
+	59: "_execve",
+}
+
+// minixCall returns the name of the MINIX system call nr.
+// It reports false if nr is not a system call number.
+func minixCall(nr uint16) (string, bool) {
+	s, ok := minixCalls[nr]
+	return s, ok
+}
+
+// minixStubLabel returns the C library label of the stub of the system call nr,
+// e.g. "_read".
+func minixStubLabel(nr uint16) (string, bool) {
+	if s, ok := minixStubs[nr]; ok {
+		return s, true
+	}
+	s, ok := minixCall(nr)
+	if !ok {
+		return "", false
+	}
+	return "_" + s, true
+}
+
+// minixIPCCall describes the interrupt n with the primitive fn in CX and the
+// destination dest in AX, e.g. "MINIX: sendrec FS".
+// It reports false if n is not the MINIX vector or fn is unknown.
+func minixIPCCall(n byte, fn, dest uint16) (string, bool) {
+	if n != minixSysVec {
+		return "", false
+	}
+	ipc, ok := minixIPC[fn]
+	if !ok {
+		return "", false
+	}
+	task, ok := minixTasks[dest]
+	if !ok {
+		task = fmt.Sprintf("task %d", int16(dest))
+	}
+	return fmt.Sprintf("MINIX: %s %s", ipc, task), true
+}
@@ -0,0 +1,49 @@
+package disasm
+
+import "testing"
+
+func TestMinixStubLabel(t *testing.T) {
+	minixTests := []struct {
+		nr   uint16
+		want string
+		ok   bool
+	}{
+		{3, "_read", true},
+		{4, "_write", true},
+		{17, "_brk", true},
+		{59, "_execve", true},
+		{64, "", false},
+		{68, "", false},
+		{11, "", false},
+		{69, "", false},
+	}
+
+	for _, tt := range minixTests {
+		got, ok := minixStubLabel(tt.nr)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("minixStubLabel(%v) = %q, %v; want %q, %v", tt.nr, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMinixIPCCall(t *testing.T) {
+	ipcTests := []struct {
+		n       byte
+		fn, dst uint16
+		want    string
+		ok      bool
+	}{
+		{0x20, 3, 1, "MINIX: sendrec FS", true},
+		{0x20, 1, 0, "MINIX: send MM", true},
+		{0x20, 2, 0xFFFE, "MINIX: receive task -2", true},
+		{0x20, 4, 0, "", false},
+		{0x21, 3, 1, "", false},
+	}
+
+	for _, tt := range ipcTests {
+		got, ok := minixIPCCall(tt.n, tt.fn, tt.dst)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("minixIPCCall(%#x, %v, %v) = %q, %v; want %q, %v", tt.n, tt.fn, tt.dst, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package disasm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HexStyle is a notation of hexadecimal numbers.
+type HexStyle int
+
+// Hex styles.
+const (
+	HexC      HexStyle = iota // 0x12
+	HexSuffix                 // 12h
+	HexDollar                 // $12
+)
+
+// ParseHexStyle returns the hex style written as "0x", "h" or "$".
+func ParseHexStyle(s string) (HexStyle, error) {
+	switch s {
+	case "0x":
+		return HexC, nil
+	case "h":
+		return HexSuffix, nil
+	case "$":
+		return HexDollar, nil
+	default:
+		return 0, fmt.Errorf("unknown hex style %q", s)
+	}
+}
+
+// Options configures the layout of a listing.
+// The zero value is the default layout.
+type Options struct {
+	SegOff   bool     // show addresses as segment:offset
+	Seg      uint16   // segment of segment:offset addresses
+	ByteCols int      // wrap the byte column at this many bytes; 0 for no wrapping
+	NoBytes  bool     // hide the byte column
+	Upper    bool     // uppercase mnemonics and registers
+	Hex      HexStyle // notation of immediates and displacements
+	Unsigned bool     // show displacements unsigned
+}
+
+// addr returns the address of the offset off.
+// In segment:offset form, offsets beyond 64 KiB move into the following
+// segments, e.g. 0x12345 in segment 0 is 1000:2345; the segment wraps
+// around at 1 MiB like the 8086 does.
+func (o Options) addr(off int) string {
+	if o.SegOff {
+		seg := o.Seg + uint16(off>>16)<<12
+		return fmt.Sprintf("%04X:%04X", seg, off&0xFFFF)
+	}
+	return fmt.Sprintf("%08X", off)
+}
+
+// name returns a mnemonic, register or size keyword in the configured case.
+func (o Options) name(s string) string {
+	if o.Upper {
+		return strings.ToUpper(s)
+	}
+	return s
+}
+
+// hex returns v in the configured hex style, padded to at least width digits.
+func (o Options) hex(v uint, width int) string {
+	s := fmt.Sprintf("%0*x", width, v)
+	switch o.Hex {
+	case HexSuffix:
+		if s[0] >= 'a' {
+			s = "0" + s
+		}
+		return s + "h"
+	case HexDollar:
+		return "$" + s
+	default:
+		return "0x" + s
+	}
+}
+
+// disp returns a displacement of size bits with a leading sign.
+func (o Options) disp(v int, size uint) string {
+	if o.Unsigned {
+		return "+" + o.hex(uint(v)&(1<<size-1), 0)
+	}
+	if v < 0 {
+		return "-" + o.hex(uint(-v), 0)
+	}
+	return "+" + o.hex(uint(v), 0)
+}
@@ -0,0 +1,81 @@
+package disasm
+
+import "testing"
+
+func TestCmdStr(t *testing.T) {
+	bs := []byte{0xFF, 0x06, 0xF2, 0x45}
+
+	cmdTests := []struct {
+		o    Options
+		want string
+	}{
+		{Options{}, "00000011  FF06F245   inc word [0x45f2]"},
+		{Options{SegOff: true, Seg: 0x1000}, "1000:0011  FF06F245   inc word [0x45f2]"},
+		{Options{NoBytes: true}, "00000011  inc word [0x45f2]"},
+		{Options{ByteCols: 6}, "00000011  FF06F245       inc word [0x45f2]"},
+		{Options{ByteCols: 3}, "00000011  FF06F2   inc word [0x45f2]\n00000014  45"},
+		{Options{Upper: true}, "00000011  FF06F245   INC WORD [0x45f2]"},
+	}
+
+	for _, tt := range cmdTests {
+		if got := tt.o.cmdStr(0x11, bs, inc, "word ", "[0x45f2]", ""); got != tt.want {
+			t.Errorf("%+v: got %q; want %q", tt.o, got, tt.want)
+		}
+	}
+}
+
+func TestAddr(t *testing.T) {
+	addrTests := []struct {
+		o    Options
+		off  int
+		want string
+	}{
+		{Options{}, 0x12345, "00012345"},
+		{Options{SegOff: true, Seg: 0x1000}, 0x6, "1000:0006"},
+		{Options{SegOff: true}, 0x12345, "1000:2345"},
+		{Options{SegOff: true, Seg: 0xF800}, 0x10006, "0800:0006"},
+	}
+
+	for _, tt := range addrTests {
+		if got := tt.o.addr(tt.off); got != tt.want {
+			t.Errorf("%+v: addr(%#x) = %v; want %v", tt.o, tt.off, got, tt.want)
+		}
+	}
+}
+
+func TestModrmOptions(t *testing.T) {
+	modrmTests := []struct {
+		o    Options
+		bs   []byte
+		want string
+	}{
+		{Options{Hex: HexSuffix}, []byte{0x06, 0xF2, 0x45}, "[45f2h]"},
+		{Options{Hex: HexSuffix}, []byte{0x06, 0x34, 0xF2}, "[0f234h]"},
+		{Options{Hex: HexDollar}, []byte{0x40, 0x12}, "[bx+si+$12]"},
+		{Options{Unsigned: true}, []byte{0x47, 0xFF}, "[bx+0xff]"},
+		{Options{Unsigned: true}, []byte{0x87, 0xFF, 0xFF}, "[bx+0xffff]"},
+		{Options{Upper: true}, []byte{0x40, 0x12}, "[BX+SI+0x12]"},
+		{Options{Upper: true}, []byte{0xC7}, "DI"},
+	}
+
+	for _, tt := range modrmTests {
+		got, err := tt.o.modrm(tt.bs)
+		if err != nil {
+			t.Errorf("error in modrm(%v): %v", tt.bs, err)
+		}
+		if got != tt.want {
+			t.Errorf("%+v: got %v; want %v", tt.o, got, tt.want)
+		}
+	}
+}
+
+func TestParseHexStyle(t *testing.T) {
+	for s, want := range map[string]HexStyle{"0x": HexC, "h": HexSuffix, "$": HexDollar} {
+		if got, err := ParseHexStyle(s); err != nil || got != want {
+			t.Errorf("ParseHexStyle(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseHexStyle("x"); err == nil {
+		t.Errorf("error should occur on %q", "x")
+	}
+}
@@ -0,0 +1,229 @@
+package disasm
+
+import "strings"
+
+// Flag is a bit of the FLAGS register.
+type Flag uint16
+
+// Flags.
+const (
+	carry     Flag = 1 << 0  // CF
+	parity    Flag = 1 << 2  // PF
+	auxCarry  Flag = 1 << 4  // AF
+	zero      Flag = 1 << 6  // ZF
+	sign      Flag = 1 << 7  // SF
+	trap      Flag = 1 << 8  // TF
+	interrupt Flag = 1 << 9  // IF
+	direction Flag = 1 << 10 // DF
+	overflow  Flag = 1 << 11 // OF
+
+	// status flags set by arithmetic
+	status = carry | parity | auxCarry | zero | sign | overflow
+	// all flags of the 8086
+	allFlags = status | trap | interrupt | direction
+)
+
+var flagNames = [...]struct {
+	f Flag
+	s string
+}{
+	{carry, "cf"}, {parity, "pf"}, {auxCarry, "af"}, {zero, "zf"}, {sign, "sf"},
+	{trap, "tf"}, {interrupt, "if"}, {direction, "df"}, {overflow, "of"},
+}
+
+// String returns the names of the flags set in f joined by '|'.
+func (f Flag) String() string {
+	var ss []string
+	for _, n := range flagNames {
+		if f&n.f != 0 {
+			ss = append(ss, n.s)
+		}
+	}
+	return strings.Join(ss, "|")
+}
+
+// Access is the way an operand or memory is accessed.
+type Access byte
+
+// Accesses.
+const (
+	read    Access = 1 << iota // value is read
+	write                      // value is written
+	address                    // only the effective address is computed
+)
+
+// Flow is the kind of control transfer of an operation.
+type Flow byte
+
+// Flows.
+const (
+	flowNext Flow = iota // falls through to the next instruction
+	flowJump             // unconditional jump
+	flowCond             // conditional jump
+	flowCall             // call
+	flowRet              // return
+	flowInt              // software interrupt
+	flowHalt             // halt
+)
+
+// Semantics describes the effects of an operation.
+// Dst and Src tell how the explicit operands are accessed;
+// everything else is implicit in the operation itself.
+type Semantics struct {
+	Dst, Src     Access
+	Reads        []Reg
+	Writes       []Reg
+	FlagsRead    Flag
+	FlagsWritten Flag
+	FlagsUndef   Flag // left undefined
+	Mem          Access
+	Flow         Flow
+}
+
+// Semantics returns the effects of m with operand size w (0: byte, 1: word).
+// far tells a far call, jmp or ret, which also transfers CS.
+// It reports false if m is not a known mnemonic.
+func (m Mnemonic) Semantics(w byte, far bool) (Semantics, bool) {
+	if w == 0 {
+		if s, ok := semantics8[m]; ok {
+			return s, true
+		}
+	}
+	s, ok := semantics[m]
+	if ok && far {
+		switch m {
+		case call:
+			// pushes CS and loads it from the target
+			s.Reads = append([]Reg{cs}, s.Reads...)
+			s.Writes = []Reg{sp, cs}
+		case jmp:
+			s.Writes = []Reg{cs}
+		case ret:
+			s.Writes = []Reg{sp, cs}
+		}
+	}
+	return s, ok
+}
+
+var (
+	// stack operations
+	stackRegs = []Reg{sp, ss}
+	// string operations on DS:SI and ES:DI
+	strRegs = []Reg{si, di, ds, es}
+)
+
+// semantics holds the effects of every mnemonic in its word form.
+var semantics = map[Mnemonic]Semantics{
+	// Data Transfer
+	mov:   {Dst: write, Src: read},
+	push:  {Dst: read, Reads: stackRegs, Writes: []Reg{sp}, Mem: write},
+	pop:   {Dst: write, Reads: stackRegs, Writes: []Reg{sp}, Mem: read},
+	xchg:  {Dst: read | write, Src: read | write},
+	in:    {Dst: write, Src: read},
+	out:   {Dst: read, Src: read},
+	xlat:  {Reads: []Reg{al, bx, ds}, Writes: []Reg{al}, Mem: read},
+	lea:   {Dst: write, Src: address},
+	lds:   {Dst: write, Src: read, Writes: []Reg{ds}},
+	les:   {Dst: write, Src: read, Writes: []Reg{es}},
+	lahf:  {Writes: []Reg{ah}, FlagsRead: status &^ overflow},
+	sahf:  {Reads: []Reg{ah}, FlagsWritten: status &^ overflow},
+	pushf: {Reads: stackRegs, Writes: []Reg{sp}, FlagsRead: allFlags, Mem: write},
+	popf:  {Reads: stackRegs, Writes: []Reg{sp}, FlagsWritten: allFlags, Mem: read},
+
+	// Arithmetic
+	add:  {Dst: read | write, Src: read, FlagsWritten: status},
+	adc:  {Dst: read | write, Src: read, FlagsRead: carry, FlagsWritten: status},
+	inc:  {Dst: read | write, FlagsWritten: status &^ carry},
+	aaa:  {Reads: []Reg{al, ah}, Writes: []Reg{al, ah}, FlagsRead: auxCarry, FlagsWritten: auxCarry | carry, FlagsUndef: overflow | sign | zero | parity},
+	daa:  {Reads: []Reg{al}, Writes: []Reg{al}, FlagsRead: auxCarry | carry, FlagsWritten: status &^ overflow, FlagsUndef: overflow},
+	sub:  {Dst: read | write, Src: read, FlagsWritten: status},
+	sbb:  {Dst: read | write, Src: read, FlagsRead: carry, FlagsWritten: status},
+	dec:  {Dst: read | write, FlagsWritten: status &^ carry},
+	neg:  {Dst: read | write, FlagsWritten: status},
+	cmp:  {Dst: read, Src: read, FlagsWritten: status},
+	aas:  {Reads: []Reg{al, ah}, Writes: []Reg{al, ah}, FlagsRead: auxCarry, FlagsWritten: auxCarry | carry, FlagsUndef: overflow | sign | zero | parity},
+	das:  {Reads: []Reg{al}, Writes: []Reg{al}, FlagsRead: auxCarry | carry, FlagsWritten: status &^ overflow, FlagsUndef: overflow},
+	mul:  {Dst: read, Reads: []Reg{ax}, Writes: []Reg{ax, dx}, FlagsWritten: carry | overflow, FlagsUndef: sign | zero | auxCarry | parity},
+	imul: {Dst: read, Reads: []Reg{ax}, Writes: []Reg{ax, dx}, FlagsWritten: carry | overflow, FlagsUndef: sign | zero | auxCarry | parity},
+	aam:  {Reads: []Reg{al}, Writes: []Reg{al, ah}, FlagsWritten: sign | zero | parity, FlagsUndef: overflow | auxCarry | carry},
+	div:  {Dst: read, Reads: []Reg{ax, dx}, Writes: []Reg{ax, dx}, FlagsUndef: status},
+	idiv: {Dst: read, Reads: []Reg{ax, dx}, Writes: []Reg{ax, dx}, FlagsUndef: status},
+	aad:  {Reads: []Reg{al, ah}, Writes: []Reg{al, ah}, FlagsWritten: sign | zero | parity, FlagsUndef: overflow | auxCarry | carry},
+	cbw:  {Reads: []Reg{al}, Writes: []Reg{ax}},
+	cwd:  {Reads: []Reg{ax}, Writes: []Reg{dx}},
+
+	// Logic
+	not:  {Dst: read | write},
+	shl:  {Dst: read | write, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+	shr:  {Dst: read | write, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+	sar:  {Dst: read | write, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+	rol:  {Dst: read | write, Src: read, FlagsWritten: carry | overflow},
+	ror:  {Dst: read | write, Src: read, FlagsWritten: carry | overflow},
+	rcl:  {Dst: read | write, Src: read, FlagsRead: carry, FlagsWritten: carry | overflow},
+	rcr:  {Dst: read | write, Src: read, FlagsRead: carry, FlagsWritten: carry | overflow},
+	and:  {Dst: read | write, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+	test: {Dst: read, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+	or:   {Dst: read | write, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+	xor:  {Dst: read | write, Src: read, FlagsWritten: status &^ auxCarry, FlagsUndef: auxCarry},
+
+	// String Manipulation
+	rep:  {Reads: []Reg{cx}, Writes: []Reg{cx}, FlagsRead: zero},
+	movs: {Reads: strRegs, Writes: []Reg{si, di}, FlagsRead: direction, Mem: read | write},
+	cmps: {Reads: strRegs, Writes: []Reg{si, di}, FlagsRead: direction, FlagsWritten: status, Mem: read},
+	scas: {Reads: []Reg{ax, di, es}, Writes: []Reg{di}, FlagsRead: direction, FlagsWritten: status, Mem: read},
+	lods: {Reads: []Reg{si, ds}, Writes: []Reg{ax, si}, FlagsRead: direction, Mem: read},
+	stos: {Reads: []Reg{ax, di, es}, Writes: []Reg{di}, FlagsRead: direction, Mem: write},
+
+	// Control Transfer
+	call:   {Dst: read, Reads: stackRegs, Writes: []Reg{sp}, Mem: write, Flow: flowCall},
+	jmp:    {Dst: read, Flow: flowJump},
+	ret:    {Dst: read, Reads: stackRegs, Writes: []Reg{sp}, Mem: read, Flow: flowRet},
+	je:     {Dst: read, FlagsRead: zero, Flow: flowCond},
+	jl:     {Dst: read, FlagsRead: sign | overflow, Flow: flowCond},
+	jle:    {Dst: read, FlagsRead: zero | sign | overflow, Flow: flowCond},
+	jb:     {Dst: read, FlagsRead: carry, Flow: flowCond},
+	jbe:    {Dst: read, FlagsRead: carry | zero, Flow: flowCond},
+	jp:     {Dst: read, FlagsRead: parity, Flow: flowCond},
+	jo:     {Dst: read, FlagsRead: overflow, Flow: flowCond},
+	js:     {Dst: read, FlagsRead: sign, Flow: flowCond},
+	jne:    {Dst: read, FlagsRead: zero, Flow: flowCond},
+	jnl:    {Dst: read, FlagsRead: sign | overflow, Flow: flowCond},
+	jnle:   {Dst: read, FlagsRead: zero | sign | overflow, Flow: flowCond},
+	jnb:    {Dst: read, FlagsRead: carry, Flow: flowCond},
+	jnbe:   {Dst: read, FlagsRead: carry | zero, Flow: flowCond},
+	jnp:    {Dst: read, FlagsRead: parity, Flow: flowCond},
+	jno:    {Dst: read, FlagsRead: overflow, Flow: flowCond},
+	jns:    {Dst: read, FlagsRead: sign, Flow: flowCond},
+	loop:   {Dst: read, Reads: []Reg{cx}, Writes: []Reg{cx}, Flow: flowCond},
+	loopz:  {Dst: read, Reads: []Reg{cx}, Writes: []Reg{cx}, FlagsRead: zero, Flow: flowCond},
+	loopnz: {Dst: read, Reads: []Reg{cx}, Writes: []Reg{cx}, FlagsRead: zero, Flow: flowCond},
+	jcxz:   {Dst: read, Reads: []Reg{cx}, Flow: flowCond},
+	intr:   {Dst: read, Reads: stackRegs, Writes: []Reg{sp, cs}, FlagsRead: allFlags, FlagsWritten: trap | interrupt, Mem: read | write, Flow: flowInt},
+	into:   {Reads: stackRegs, Writes: []Reg{sp, cs}, FlagsRead: allFlags, FlagsWritten: trap | interrupt, Mem: read | write, Flow: flowInt},
+	iret:   {Reads: stackRegs, Writes: []Reg{sp, cs}, FlagsWritten: allFlags, Mem: read, Flow: flowRet},
+
+	// Processor Control
+	clc:  {FlagsWritten: carry},
+	cmc:  {FlagsRead: carry, FlagsWritten: carry},
+	stc:  {FlagsWritten: carry},
+	cld:  {FlagsWritten: direction},
+	std:  {FlagsWritten: direction},
+	cli:  {FlagsWritten: interrupt},
+	sti:  {FlagsWritten: interrupt},
+	hlt:  {Flow: flowHalt},
+	wait: {},
+	esc:  {Src: read},
+	lock: {},
+}
+
+// semantics8 holds the effects of mnemonics whose byte form
+// differs from the word form in implicit registers.
+var semantics8 = map[Mnemonic]Semantics{
+	mul:  {Dst: read, Reads: []Reg{al}, Writes: []Reg{ax}, FlagsWritten: carry | overflow, FlagsUndef: sign | zero | auxCarry | parity},
+	imul: {Dst: read, Reads: []Reg{al}, Writes: []Reg{ax}, FlagsWritten: carry | overflow, FlagsUndef: sign | zero | auxCarry | parity},
+	div:  {Dst: read, Reads: []Reg{ax}, Writes: []Reg{al, ah}, FlagsUndef: status},
+	idiv: {Dst: read, Reads: []Reg{ax}, Writes: []Reg{al, ah}, FlagsUndef: status},
+	scas: {Reads: []Reg{al, di, es}, Writes: []Reg{di}, FlagsRead: direction, FlagsWritten: status, Mem: read},
+	lods: {Reads: []Reg{si, ds}, Writes: []Reg{al, si}, FlagsRead: direction, Mem: read},
+	stos: {Reads: []Reg{al, di, es}, Writes: []Reg{di}, FlagsRead: direction, Mem: write},
+}
@@ -0,0 +1,64 @@
+package disasm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSemanticsCoverAllMnemonics(t *testing.T) {
+	for m := mov; m <= lock; m++ {
+		if _, ok := m.Semantics(1, false); !ok {
+			t.Errorf("%v has no semantics", m)
+		}
+	}
+
+	if _, ok := Mnemonic(0).Semantics(1, false); ok {
+		t.Errorf("Mnemonic(0) should have no semantics")
+	}
+}
+
+func TestSemantics(t *testing.T) {
+	semTests := []struct {
+		m      Mnemonic
+		w      byte
+		far    bool
+		reads  []Reg
+		writes []Reg
+		fr     Flag
+		flow   Flow
+	}{
+		{mul, 0, false, []Reg{al}, []Reg{ax}, 0, flowNext},
+		{mul, 1, false, []Reg{ax}, []Reg{ax, dx}, 0, flowNext},
+		{cwd, 1, false, []Reg{ax}, []Reg{dx}, 0, flowNext},
+		{lods, 0, false, []Reg{si, ds}, []Reg{al, si}, direction, flowNext},
+		{adc, 1, false, nil, nil, carry, flowNext},
+		{jnbe, 1, false, nil, nil, carry | zero, flowCond},
+		{loop, 1, false, []Reg{cx}, []Reg{cx}, 0, flowCond},
+		{ret, 1, false, stackRegs, []Reg{sp}, 0, flowRet},
+		{ret, 1, true, stackRegs, []Reg{sp, cs}, 0, flowRet},
+		{call, 1, true, []Reg{cs, sp, ss}, []Reg{sp, cs}, 0, flowCall},
+		{jmp, 1, true, nil, []Reg{cs}, 0, flowJump},
+		{jmp, 1, false, nil, nil, 0, flowJump},
+		{hlt, 1, false, nil, nil, 0, flowHalt},
+	}
+
+	for _, tt := range semTests {
+		got, _ := tt.m.Semantics(tt.w, tt.far)
+		if !reflect.DeepEqual(got.Reads, tt.reads) || !reflect.DeepEqual(got.Writes, tt.writes) {
+			t.Errorf("%v (w=%v, far=%v): got reads %v writes %v; want %v %v",
+				tt.m, tt.w, tt.far, got.Reads, got.Writes, tt.reads, tt.writes)
+		}
+		if got.FlagsRead != tt.fr {
+			t.Errorf("%v: got flags read %v; want %v", tt.m, got.FlagsRead, tt.fr)
+		}
+		if got.Flow != tt.flow {
+			t.Errorf("%v: got flow %v; want %v", tt.m, got.Flow, tt.flow)
+		}
+	}
+}
+
+func TestFlagString(t *testing.T) {
+	if got, want := (carry | zero | overflow).String(), "cf|zf|of"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/skatsuta/gdisasm/disasm"
 	"github.com/skatsuta/gdisasm/log"
+	"github.com/skatsuta/gdisasm/symbol"
 )
 
 const bsize = 2
@@ -15,6 +16,21 @@ const bsize = 2
 // logger is a logging object.
 var logger log.Logger
 
+// layout flags; they take effect once Disasm.Parse decodes instructions
+var (
+	seg      = flag.Int("seg", -1, "show addresses as segment:offset in segment `SEG` (e.g. 0x1000)")
+	byteCols = flag.Int("bytes", 0, "wrap the byte column at `N` bytes; -1 hides it")
+	upper    = flag.Bool("upper", false, "uppercase mnemonics and registers")
+	hexStyle = flag.String("hex", "0x", "hex style of numbers: 0x, h or $")
+	unsigned = flag.Bool("unsigned", false, "show displacements unsigned")
+)
+
+// symbol flags
+var (
+	symFile = flag.String("symbols", "", "read labels, comments and ranges from `FILE`")
+	mapFile = flag.String("map", "", "read labels from a linker map or nm output `FILE`")
+)
+
 func init() {
 	logger = log.NewLogger()
 }
@@ -24,6 +40,39 @@ func main() {
 
 	file := flag.Args()[0]
 
+	if *seg < -1 || *seg > 0xFFFF {
+		logger.Err("-seg: %#x is not a 16-bit segment", *seg)
+		return
+	}
+	hs, err := disasm.ParseHexStyle(*hexStyle)
+	if err != nil {
+		logger.Err("-hex: %v", err)
+		return
+	}
+	opts := disasm.Options{
+		SegOff:   *seg >= 0,
+		Seg:      uint16(*seg),
+		ByteCols: *byteCols,
+		NoBytes:  *byteCols < 0,
+		Upper:    *upper,
+		Hex:      hs,
+		Unsigned: *unsigned,
+	}
+
+	// the tables are only validated for now; the decoder does not apply them yet
+	if *symFile != "" {
+		if _, err := symbol.Load(*symFile); err != nil {
+			logger.Err("symbol.Load(%v) failed: %v", *symFile, err)
+			return
+		}
+	}
+	if *mapFile != "" {
+		if _, err := symbol.LoadMap(*mapFile); err != nil {
+			logger.Err("symbol.LoadMap(%v) failed: %v", *mapFile, err)
+			return
+		}
+	}
+
 	fp, err := os.Open(file)
 	if err != nil {
 		logger.Err("os.Open(%v) failed: %v", file, err)
@@ -32,7 +81,7 @@ func main() {
 	r := bufio.NewReader(fp)
 	w := bufio.NewWriter(os.Stdout)
 
-	d := disasm.New(r, w)
+	d := disasm.NewWithOptions(r, w, opts)
 
 	for {
 		s, err := d.Parse()
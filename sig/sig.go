@@ -0,0 +1,214 @@
+// Package sig names library functions in a binary by byte pattern signatures.
+//
+// A signature file has one signature per line: a pattern of hexadecimal bytes,
+// where ".." matches any byte, followed by the function name.
+// '#' starts a comment line.
+//
+//	5589E5568B7604E8....5EC3 _strlen
+package sig
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// wildcard is the textual form of a byte matching any value.
+const wildcard = ".."
+
+// minFixed is the minimum number of bytes of a pattern that are not wildcards.
+// Shorter patterns match almost anywhere.
+const minFixed = 3
+
+// Signature is a byte pattern of a function.
+type Signature struct {
+	Name string
+	Pat  []byte
+	Mask []bool // true where Pat must match
+}
+
+// Hit is a function found in a binary.
+type Hit struct {
+	Off  int
+	Name string
+}
+
+// Generate returns the signature of the function name whose code is code.
+// The words at the offsets relocs are relocated by the linker and match any value.
+func Generate(name string, code []byte, relocs []int) (Signature, error) {
+	s := Signature{
+		Name: name,
+		Pat:  append([]byte(nil), code...),
+		Mask: make([]bool, len(code)),
+	}
+	for i := range s.Mask {
+		s.Mask[i] = true
+	}
+
+	for _, r := range relocs {
+		if r < 0 || r+2 > len(code) {
+			return Signature{}, fmt.Errorf("relocation at %#x is out of %v bytes of %v", r, len(code), name)
+		}
+		s.Pat[r], s.Pat[r+1] = 0, 0
+		s.Mask[r], s.Mask[r+1] = false, false
+	}
+
+	if s.fixed() < minFixed {
+		return Signature{}, fmt.Errorf("signature of %v has fewer than %v fixed bytes", name, minFixed)
+	}
+	return s, nil
+}
+
+// fixed returns the number of bytes of s that are not wildcards.
+func (s Signature) fixed() int {
+	n := 0
+	for _, m := range s.Mask {
+		if m {
+			n++
+		}
+	}
+	return n
+}
+
+// valid reports whether s has a mask for every byte and at least minFixed fixed bytes.
+func (s Signature) valid() bool {
+	return len(s.Mask) == len(s.Pat) && s.fixed() >= minFixed
+}
+
+// String returns s in the signature file syntax.
+// Bytes without a mask entry are written as wildcards.
+func (s Signature) String() string {
+	var b strings.Builder
+	for i, c := range s.Pat {
+		if i < len(s.Mask) && s.Mask[i] {
+			fmt.Fprintf(&b, "%02X", c)
+		} else {
+			b.WriteString(wildcard)
+		}
+	}
+	return b.String() + " " + s.Name
+}
+
+// matchAt reports whether s matches bs at the offset off.
+func (s Signature) matchAt(bs []byte, off int) bool {
+	if off+len(s.Pat) > len(bs) {
+		return false
+	}
+	for i, c := range s.Pat {
+		if s.Mask[i] && bs[off+i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// Match finds the functions of sigs in bs. Where several signatures match at
+// the same offset, the longest one wins; hits do not overlap.
+// Invalid signatures, built by hand with a mask that does not cover the
+// pattern or with fewer than minFixed fixed bytes, are ignored.
+func Match(bs []byte, sigs []Signature) []Hit {
+	var hits []Hit
+
+	for off := 0; off < len(bs); {
+		var best *Signature
+		for i := range sigs {
+			s := &sigs[i]
+			if !s.valid() || !s.matchAt(bs, off) {
+				continue
+			}
+			if best == nil || len(s.Pat) > len(best.Pat) {
+				best = s
+			}
+		}
+
+		if best == nil {
+			off++
+			continue
+		}
+		hits = append(hits, Hit{Off: off, Name: best.Name})
+		off += len(best.Pat)
+	}
+
+	return hits
+}
+
+// Load reads a signature file.
+func Load(file string) ([]Signature, error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	sigs, err := Read(fp)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return sigs, nil
+}
+
+// Read reads signatures in the signature file syntax from r.
+func Read(r io.Reader) ([]Signature, error) {
+	var sigs []Signature
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fs := strings.Fields(line)
+		if len(fs) != 2 {
+			return nil, fmt.Errorf("line %v: want a pattern and a name: %q", n, line)
+		}
+		s, err := parsePat(fs[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n, err)
+		}
+		s.Name = fs[1]
+		sigs = append(sigs, s)
+	}
+
+	return sigs, sc.Err()
+}
+
+// Write writes sigs to w in the signature file syntax.
+func Write(w io.Writer, sigs []Signature) error {
+	for _, s := range sigs {
+		if _, err := fmt.Fprintln(w, s.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePat parses a pattern of hexadecimal bytes and wildcards.
+func parsePat(p string) (Signature, error) {
+	if len(p)%2 != 0 {
+		return Signature{}, fmt.Errorf("pattern %q has an odd length", p)
+	}
+
+	var s Signature
+	for i := 0; i < len(p); i += 2 {
+		if p[i:i+2] == wildcard {
+			s.Pat = append(s.Pat, 0)
+			s.Mask = append(s.Mask, false)
+			continue
+		}
+		b, err := hex.DecodeString(p[i : i+2])
+		if err != nil {
+			return Signature{}, fmt.Errorf("invalid byte %q in pattern %q", p[i:i+2], p)
+		}
+		s.Pat = append(s.Pat, b[0])
+		s.Mask = append(s.Mask, true)
+	}
+
+	if s.fixed() < minFixed {
+		return Signature{}, fmt.Errorf("pattern %q has fewer than %v fixed bytes", p, minFixed)
+	}
+	return s, nil
+}
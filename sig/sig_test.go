@@ -0,0 +1,78 @@
+package sig
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// code at 0x154 of test/cc: push bp; mov bp,sp; sub sp,byte +0xc; push si; push di;
+// mov ax,0xc5a; push ax; call word 0x172e
+var code = []byte{0x55, 0x89, 0xE5, 0x83, 0xEC, 0x0C, 0x56, 0x57, 0xB8, 0x5A, 0x0C, 0x50, 0xE8, 0xCB, 0x15}
+
+func TestGenerate(t *testing.T) {
+	s, err := Generate("_main", code, []int{9, 13})
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if got, want := s.String(), "5589E583EC0C5657B8....50E8.... _main"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	if _, err := Generate("_main", code, []int{14}); err == nil {
+		t.Errorf("error should occur on a relocation past the end")
+	}
+	if _, err := Generate("_far", code[:4], []int{0, 2}); err == nil {
+		t.Errorf("error should occur on a signature of wildcards only")
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	src := "# libc\n5589E583EC0C5657B8....50E8.... _main\n5589E5 _enter\n"
+
+	sigs, err := Read(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, sigs); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if got, want := buf.String(), src[len("# libc\n"):]; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	for _, tt := range []string{"5589E _odd", "5589ZZ _bad", "5589E5", "........ _any", "55....89 _short"} {
+		if _, err := Read(bytes.NewBufferString(tt)); err == nil {
+			t.Errorf("error should occur on %q", tt)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	sigs, err := Read(bytes.NewBufferString("5589E583EC0C5657B8....50E8.... _main\n5589E5 _enter\n"))
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	// a relocated copy of code after a hlt, then a bare frame setup
+	bs := append([]byte{0xF4}, code...)
+	bs[10], bs[11] = 0x34, 0x12
+	bs = append(bs, 0x55, 0x89, 0xE5, 0xC3)
+
+	// a signature built by hand without fixed bytes never matches
+	sigs = append(sigs, Signature{Name: "_any", Pat: make([]byte, 2), Mask: make([]bool, 2)})
+	// nor does one whose mask is shorter than its pattern
+	sigs = append(sigs, Signature{Name: "_short", Pat: []byte{0x55, 0x89, 0xE5, 0xC3}, Mask: []bool{true, true, true}})
+
+	if got, want := sigs[len(sigs)-1].String(), "5589E5.. _short"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	got := Match(bs, sigs)
+	want := []Hit{{Off: 1, Name: "_main"}, {Off: 16, Name: "_enter"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package symbol
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// segment:offset symbols of MS LINK, TLINK and WLINK maps, e.g.
+	//	" 0000:0154       _main"      (MS LINK, TLINK)
+	//	" 0000:0010  Abs  _edata"     (MS LINK)
+	//	" 0000:0156 idle  _foo"       (TLINK, unreferenced)
+	//	"0000:0154*     main_"        (WLINK)
+	segOffRe = regexp.MustCompile(`^\s*([0-9A-Fa-f]{4}):([0-9A-Fa-f]{4})[*+]?\s+(?:(Abs|Imp|Res|idle)\s+)?(\S+)\s*$`)
+	// entry points of MS LINK, TLINK and WLINK maps, e.g.
+	//	"Program entry point at 0000:0000"
+	//	"Entry point address: 0000:0000"
+	entryRe = regexp.MustCompile(`(?i)entry point (?:at|address:)\s+([0-9A-F]{4}):([0-9A-F]{4})`)
+	// symbols of NASM map files, e.g.
+	//	"             154               154  main"
+	nasmRe = regexp.MustCompile(`^\s*([0-9A-Fa-f]+)\s+[0-9A-Fa-f]+\s+(\S+)\s*$`)
+	// symbols of ACK nm output, e.g.
+	//	"0x0154 T _main" or "000154 T _main"
+	nmRe = regexp.MustCompile(`^\s*(?:0x)?([0-9A-Fa-f]+)\s+([A-Za-z])\s+(\S+)\s*$`)
+	// header of a symbol list in NASM map files
+	nasmHeader = regexp.MustCompile(`^Real\s+Virtual\s+Name`)
+)
+
+// LoadMap reads a linker map file or nm output.
+func LoadMap(file string) (*Table, error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	t := NewTable()
+	if err := t.ReadMap(fp); err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return t, nil
+}
+
+// ReadMap reads labels and entry points from r into t. r is a map file of
+// MS LINK (also written for MASM), TLINK, WLINK or NASM, or the output of ACK nm.
+// segment:offset addresses are converted to linear addresses.
+// Lines that do not name a symbol are skipped.
+func (t *Table) ReadMap(r io.Reader) error {
+	// NASM lists symbols after a "Real  Virtual  Name" header only.
+	nasm := false
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+
+		if m := entryRe.FindStringSubmatch(line); m != nil {
+			t.Entries = append(t.Entries, linear(m[1], m[2]))
+			continue
+		}
+
+		if m := segOffRe.FindStringSubmatch(line); m != nil {
+			// absolute symbols are constants, not addresses
+			if m[3] != "Abs" {
+				t.Labels[linear(m[1], m[2])] = m[4]
+			}
+			continue
+		}
+
+		if m := nmRe.FindStringSubmatch(line); m != nil {
+			// absolute symbols are constants, not addresses
+			if m[2] == "a" || m[2] == "A" {
+				continue
+			}
+			off, err := strconv.ParseUint(m[1], 16, 32)
+			if err != nil {
+				return fmt.Errorf("invalid address %q", m[1])
+			}
+			t.Labels[int(off)] = m[3]
+			continue
+		}
+
+		if nasmHeader.MatchString(line) {
+			nasm = true
+			continue
+		}
+		if nasm {
+			if m := nasmRe.FindStringSubmatch(line); m != nil {
+				off, err := strconv.ParseUint(m[1], 16, 32)
+				if err != nil {
+					return fmt.Errorf("invalid address %q", m[1])
+				}
+				t.Labels[int(off)] = m[2]
+			}
+		}
+	}
+
+	return sc.Err()
+}
+
+// linear returns the linear address of hexadecimal seg:off.
+func linear(seg, off string) int {
+	s, _ := strconv.ParseUint(seg, 16, 16)
+	o, _ := strconv.ParseUint(off, 16, 16)
+	return int(s)<<4 + int(o)
+}
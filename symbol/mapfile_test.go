@@ -0,0 +1,119 @@
+package symbol
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadMap(t *testing.T) {
+	mapTests := []struct {
+		name    string
+		src     string
+		labels  map[int]string
+		entries []int
+	}{
+		{
+			"MS LINK",
+			`
+ Start  Stop   Length Name               Class
+ 00000H 0125FH 01260H _TEXT              CODE
+ 01260H 0146FH 00210H _DATA              DATA
+
+  Address         Publics by Name
+
+ 0000:0154       _main
+ 0126:0010  Abs  _edata
+
+Program entry point at 0000:0000
+`,
+			map[int]string{0x154: "_main"},
+			[]int{0x0},
+		},
+		{
+			"TLINK",
+			`
+ Start  Stop   Length Name               Class
+
+ 00000H 0125FH 01260H _TEXT              CODE
+ 01260H 0146FH 00210H _DATA              DATA
+
+  Address         Publics by Name
+
+ 0000:0154       _main
+ 0000:11DF idle  _exit
+ 0000:9876  Abs  __acrtused
+
+  Address         Publics by Value
+
+ 0000:0154       _main
+ 0000:11DF idle  _exit
+ 0000:9876  Abs  __acrtused
+
+Program entry point at 0000:0000
+`,
+			map[int]string{0x154: "_main", 0x11df: "_exit"},
+			[]int{0x0},
+		},
+		{
+			"WLINK",
+			`
+Address        Symbol
+=======        ======
+
+Module: cc.obj(cc.c)
+0000:0154      main_
+0000:11df*     exit_
+
+Entry point address: 0000:0000
+`,
+			map[int]string{0x154: "main_", 0x11df: "exit_"},
+			[]int{0x0},
+		},
+		{
+			"NASM",
+			`
+-- Sections (summary) ---------------------------------------------------------
+
+Vstart            Start             Stop              Length    Class     Name
+             100               100               1A3  000000A3  progbits  .text
+
+-- Symbols --------------------------------------------------------------------
+
+---- Section .text ------------------------------------------------------------
+
+Real              Virtual           Name
+             100               100  start
+             10A               10A  loop
+`,
+			map[int]string{0x100: "start", 0x10a: "loop"},
+			nil,
+		},
+		{
+			"ACK nm",
+			`
+000154 T _main
+0x11df T _exit
+0x9876 A __acrtused
+000010 a _nbuf
+       U _environ
+`,
+			map[int]string{0x154: "_main", 0x11df: "_exit"},
+			nil,
+		},
+	}
+
+	for _, tt := range mapTests {
+		got := NewTable()
+		if err := got.ReadMap(strings.NewReader(tt.src)); err != nil {
+			t.Errorf("%v: ReadMap() failed: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got.Labels, tt.labels) {
+			t.Errorf("%v: got labels %v; want %v", tt.name, got.Labels, tt.labels)
+		}
+		if !reflect.DeepEqual(got.Entries, tt.entries) {
+			t.Errorf("%v: got entries %v; want %v", tt.name, got.Entries, tt.entries)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package symbol reads reverse engineering knowledge about a binary:
+// labels, comments, code and data ranges, entry points and segment assumptions.
+//
+// A symbol file has one directive per line. Fields are separated by spaces,
+// numbers are in C syntax (e.g. 0x154, 340) and '#' starts a comment line.
+//
+//	label   0x0154 main
+//	comment 0x0038 halts after exit
+//	code    0x0000 0x0039
+//	data    0x0de2 0x0ed2 string
+//	entry   0x0000
+//	assume  0x0000 ds 0x0800
+package symbol
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Range is a range [Start, End) of offsets known to be code or data.
+type Range struct {
+	Start, End int
+	Code       bool
+	Type       string // data type such as byte, word, string or ptr
+}
+
+// Assume is an assumption that a segment register holds Val from offset Off.
+type Assume struct {
+	Off  int
+	Sreg string
+	Val  int
+}
+
+// Table is a set of symbols of a binary.
+type Table struct {
+	Labels   map[int]string
+	Comments map[int]string
+	Ranges   []Range
+	Entries  []int
+	Assumes  []Assume
+}
+
+// NewTable returns a new empty Table.
+func NewTable() *Table {
+	return &Table{
+		Labels:   make(map[int]string),
+		Comments: make(map[int]string),
+	}
+}
+
+// sregs are the names of segment registers that can be assumed.
+var sregs = map[string]bool{"es": true, "cs": true, "ss": true, "ds": true}
+
+// dataTypes are the types of data ranges.
+var dataTypes = map[string]bool{"byte": true, "word": true, "string": true, "ptr": true}
+
+// Load reads a symbol file.
+func Load(file string) (*Table, error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	t := NewTable()
+	if err := t.Read(fp); err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return t, nil
+}
+
+// Read reads symbol directives from r into t.
+func (t *Table) Read(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := t.parseLine(line); err != nil {
+			return fmt.Errorf("line %v: %v", n, err)
+		}
+	}
+	return sc.Err()
+}
+
+// IsCode reports whether off lies in a range declared as code.
+func (t *Table) IsCode(off int) bool {
+	for _, r := range t.Ranges {
+		if r.Code && r.Start <= off && off < r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLine parses a directive and adds it to t.
+func (t *Table) parseLine(line string) error {
+	fs := strings.Fields(line)
+
+	switch fs[0] {
+	case "label":
+		if len(fs) != 3 {
+			return fmt.Errorf("label takes an offset and a name: %q", line)
+		}
+		off, err := parseNum(fs[1])
+		if err != nil {
+			return err
+		}
+		t.Labels[off] = fs[2]
+	case "comment":
+		if len(fs) < 3 {
+			return fmt.Errorf("comment takes an offset and a text: %q", line)
+		}
+		off, err := parseNum(fs[1])
+		if err != nil {
+			return err
+		}
+		t.Comments[off] = strings.Join(fs[2:], " ")
+	case "code", "data":
+		if len(fs) < 3 || len(fs) > 4 || fs[0] == "code" && len(fs) != 3 {
+			return fmt.Errorf("%v takes a start and an end offset: %q", fs[0], line)
+		}
+		start, err := parseNum(fs[1])
+		if err != nil {
+			return err
+		}
+		end, err := parseNum(fs[2])
+		if err != nil {
+			return err
+		}
+		if end < start {
+			return fmt.Errorf("end %#x is before start %#x", end, start)
+		}
+		r := Range{Start: start, End: end, Code: fs[0] == "code"}
+		if len(fs) == 4 {
+			if !dataTypes[fs[3]] {
+				return fmt.Errorf("%q is not a data type", fs[3])
+			}
+			r.Type = fs[3]
+		}
+		t.Ranges = append(t.Ranges, r)
+	case "entry":
+		if len(fs) != 2 {
+			return fmt.Errorf("entry takes an offset: %q", line)
+		}
+		off, err := parseNum(fs[1])
+		if err != nil {
+			return err
+		}
+		t.Entries = append(t.Entries, off)
+	case "assume":
+		if len(fs) != 4 {
+			return fmt.Errorf("assume takes an offset, a segment register and a value: %q", line)
+		}
+		off, err := parseNum(fs[1])
+		if err != nil {
+			return err
+		}
+		if !sregs[fs[2]] {
+			return fmt.Errorf("%q is not a segment register", fs[2])
+		}
+		val, err := parseNum(fs[3])
+		if err != nil {
+			return err
+		}
+		if val > 0xFFFF {
+			return fmt.Errorf("segment value %#x exceeds 16 bits", val)
+		}
+		t.Assumes = append(t.Assumes, Assume{Off: off, Sreg: fs[2], Val: val})
+	default:
+		return fmt.Errorf("unknown directive %q", fs[0])
+	}
+
+	return nil
+}
+
+// parseNum parses a non-negative number in C syntax.
+func parseNum(s string) (int, error) {
+	n, err := strconv.ParseUint(s, 0, 31)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,64 @@
+package symbol
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	src := `# test/cc
+label 0x0154 main
+label 0x11df _exit
+comment 0x0038 halts after exit
+
+code 0x0000 0x0039
+data 0x0de2 0x0ed2 string
+entry 0x0000
+assume 0x0000 ds 0x0800
+`
+	got := NewTable()
+	if err := got.Read(strings.NewReader(src)); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	want := &Table{
+		Labels:   map[int]string{0x154: "main", 0x11df: "_exit"},
+		Comments: map[int]string{0x38: "halts after exit"},
+		Ranges: []Range{
+			{Start: 0x0, End: 0x39, Code: true},
+			{Start: 0xde2, End: 0xed2, Type: "string"},
+		},
+		Entries: []int{0x0},
+		Assumes: []Assume{{Off: 0x0, Sreg: "ds", Val: 0x800}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+
+	if !got.IsCode(0x38) || got.IsCode(0x39) || got.IsCode(0xde2) {
+		t.Errorf("IsCode() does not follow the code range [0x0, 0x39)")
+	}
+}
+
+func TestReadError(t *testing.T) {
+	readTests := []string{
+		"label 0x10",
+		"label zz main",
+		"code 0x10 0x0",
+		"code 0x0 0x10 word",
+		"data 0x0 0x10 strnig",
+		"assume 0x0 ax 0x10",
+		"assume 0x0 ds 0x123456",
+		"label -5 x",
+		"entry -0x10",
+		"symbol 0x0 main",
+	}
+
+	for _, tt := range readTests {
+		if err := NewTable().Read(strings.NewReader(tt)); err == nil {
+			t.Errorf("error should occur on %q", tt)
+		}
+	}
+}